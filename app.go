@@ -3,24 +3,38 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+
+	"github.com/iDCoded/peeq/dsn"
+	"github.com/iDCoded/peeq/internal/configmigrate"
+	"github.com/iDCoded/peeq/internal/vault"
 )
 
-// Represents a saved database connection
+// Represents a saved database connection. DSN is the legacy plaintext column
+// and is only ever populated for rows not yet migrated to EncryptedDSN/Nonce;
+// none of the three are exposed over JSON since they're sensitive.
 type Connection struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name"`
-	Type      string    `json:"type"` // Postgres, SQLite, MySQL, etc...
-	DSN       string    `json:"dsn"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Name         string    `json:"name"`
+	Type         string    `json:"type"` // Postgres, SQLite, MySQL, etc...
+	DSN          string    `json:"-"`
+	EncryptedDSN []byte    `json:"-" gorm:"column:encrypted_dsn"`
+	Nonce        []byte    `json:"-"`
+	RedactedDSN  string    `json:"dsn,omitempty" gorm:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
 type TableInfo struct {
@@ -32,6 +46,7 @@ type TableInfo struct {
 type ColumnInfo struct {
 	Name         string `json:"name"`
 	Type         string `json:"type"`
+	DBType       string `json:"db_type,omitempty"`
 	Nullable     bool   `json:"nullable"`
 	DefaultValue string `json:"default_value,omitempty"`
 	IsPrimaryKey bool   `json:"is_primary_key"`
@@ -43,12 +58,23 @@ type TableData struct {
 	Total   int64                    `json:"total"`
 }
 
+// BinaryValue is the JSON shape for a BYTEA/BLOB/BINARY cell value. Encoding
+// carries an explicit discriminator alongside the data itself, so a consumer
+// can tell a binary cell apart from an ordinary string without cross-
+// referencing the column's DBType.
+type BinaryValue struct {
+	Encoding string `json:"encoding"`
+	Data     string `json:"data"`
+}
+
 // App struct
 type App struct {
 	ctx          context.Context
 	configDB     *gorm.DB
 	activeDB     *gorm.DB
 	activeConnID uint
+	masterKey    vault.MasterKey
+	vaultLocked  bool
 }
 
 // NewApp creates a new App application struct
@@ -66,14 +92,197 @@ func (a *App) startup(ctx context.Context) {
 		log.Fatal("[Config] Failed to initialize config database:", err)
 	}
 
+	// The OS keychain isn't available on every machine (headless Linux,
+	// some containers). Rather than crashing the app, leave the vault
+	// locked and let the frontend prompt for a passphrase via UnlockVault.
+	if err := a.unlockVault(""); err != nil {
+		a.vaultLocked = true
+		log.Println("[Vault] Master key unavailable, waiting for a passphrase:", err)
+	}
+
 	log.Println("[Peeq] Application initialized successfully")
 }
 
+// IsVaultLocked reports whether the master key failed to load at startup
+// (typically because the OS keychain is unavailable) and the frontend should
+// prompt the user for a passphrase and call UnlockVault.
+func (a *App) IsVaultLocked() bool {
+	return a.vaultLocked
+}
+
+// UnlockVault derives the master key from a user-supplied passphrase and
+// retries the startup steps that require it. Intended to be called by the
+// frontend, through the Wails runtime, when IsVaultLocked reports true.
+func (a *App) UnlockVault(passphrase string) error {
+	if passphrase == "" {
+		return fmt.Errorf("passphrase is required")
+	}
+	if err := a.unlockVault(passphrase); err != nil {
+		return err
+	}
+	a.vaultLocked = false
+	return nil
+}
+
+// unlockVault loads the master key and migrates any legacy plaintext DSNs
+// under it. Shared by startup (passphrase "", keychain preferred) and
+// UnlockVault (passphrase required, keychain unavailable).
+func (a *App) unlockVault(passphrase string) error {
+	if err := a.initMasterKey(passphrase); err != nil {
+		return err
+	}
+
+	if err := a.migrateLegacyPlaintextDSNs(); err != nil {
+		return fmt.Errorf("failed to migrate legacy connection credentials: %v", err)
+	}
+
+	return nil
+}
+
+// initMasterKey loads the AES-256-GCM key used to encrypt saved connection
+// DSNs from the OS keychain, generating one on first run. If the keychain is
+// unavailable, passphrase derives the key instead; pass "" to require the
+// keychain.
+func (a *App) initMasterKey(passphrase string) error {
+	key, err := vault.LoadOrCreateMasterKey(passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to load master key: %v", err)
+	}
+
+	a.masterKey = key
+	log.Println("[Vault] Master key ready")
+	return nil
+}
+
+// migrateLegacyPlaintextDSNs re-encrypts any connection still holding a
+// plaintext DSN from before encryption-at-rest was added, then clears the
+// plaintext column. Safe to call on every startup: connections with no
+// plaintext DSN are left untouched.
+func (a *App) migrateLegacyPlaintextDSNs() error {
+	var legacy []Connection
+	if err := a.configDB.Where("dsn IS NOT NULL AND dsn != ''").Find(&legacy).Error; err != nil {
+		return fmt.Errorf("failed to list legacy connections: %v", err)
+	}
+
+	for _, conn := range legacy {
+		ciphertext, nonce, err := a.encryptDSN(conn.DSN)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt connection %d: %v", conn.ID, err)
+		}
+
+		if err := a.configDB.Model(&Connection{}).Where("id = ?", conn.ID).
+			Updates(map[string]interface{}{
+				"encrypted_dsn": ciphertext,
+				"nonce":         nonce,
+				"dsn":           nil,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to persist re-encrypted connection %d: %v", conn.ID, err)
+		}
+	}
+
+	if len(legacy) > 0 {
+		log.Printf("[Vault] Re-encrypted %d legacy connection(s)", len(legacy))
+	}
+	return nil
+}
+
+// encryptDSN seals rawDSN under the app's master key for storage in
+// Connection.EncryptedDSN/Nonce.
+func (a *App) encryptDSN(rawDSN string) (ciphertext, nonce []byte, err error) {
+	if a.vaultLocked {
+		return nil, nil, fmt.Errorf("vault is locked: call UnlockVault with a passphrase first")
+	}
+
+	ciphertext, nonce, err = vault.Encrypt(a.masterKey, rawDSN)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt connection DSN: %v", err)
+	}
+	return ciphertext, nonce, nil
+}
+
+// decryptDSN opens a Connection's EncryptedDSN/Nonce under the app's master key.
+func (a *App) decryptDSN(ciphertext, nonce []byte) (string, error) {
+	if a.vaultLocked {
+		return "", fmt.Errorf("vault is locked: call UnlockVault with a passphrase first")
+	}
+
+	rawDSN, err := vault.Decrypt(a.masterKey, ciphertext, nonce)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt connection DSN: %v", err)
+	}
+	return rawDSN, nil
+}
+
+// RotateMasterKey re-encrypts every saved connection's DSN under a new master
+// key inside a single transaction, then adopts that key for future use. If
+// oldPass is non-empty it must derive the key currently in use, as a
+// confirmation check before rotating. If newPass is empty, a fresh random key
+// is generated and stored in the OS keychain; otherwise the new key is
+// derived from newPass and the keychain is left untouched.
+func (a *App) RotateMasterKey(oldPass, newPass string) error {
+	if a.vaultLocked {
+		return fmt.Errorf("vault is locked: call UnlockVault with a passphrase first")
+	}
+
+	if oldPass != "" && vault.DeriveMasterKey(oldPass) != a.masterKey {
+		return fmt.Errorf("old passphrase does not match the current master key")
+	}
+
+	var newKey vault.MasterKey
+	if newPass != "" {
+		newKey = vault.DeriveMasterKey(newPass)
+	} else {
+		generated, err := vault.GenerateMasterKey()
+		if err != nil {
+			return fmt.Errorf("failed to generate new master key: %v", err)
+		}
+		newKey = generated
+	}
+
+	oldKey := a.masterKey
+
+	err := a.configDB.Transaction(func(tx *gorm.DB) error {
+		var connections []Connection
+		if err := tx.Find(&connections).Error; err != nil {
+			return fmt.Errorf("failed to list connections: %v", err)
+		}
+
+		for _, conn := range connections {
+			ciphertext, nonce, err := vault.Rotate(oldKey, newKey, conn.EncryptedDSN, conn.Nonce)
+			if err != nil {
+				return fmt.Errorf("failed to rotate connection %d: %v", conn.ID, err)
+			}
+
+			if err := tx.Model(&Connection{}).Where("id = ?", conn.ID).
+				Updates(map[string]interface{}{
+					"encrypted_dsn": ciphertext,
+					"nonce":         nonce,
+				}).Error; err != nil {
+				return fmt.Errorf("failed to persist rotated connection %d: %v", conn.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if newPass == "" {
+		if err := vault.StoreMasterKey(newKey); err != nil {
+			log.Printf("[Vault] Warning: rotated master key was not persisted to the keychain: %v", err)
+		}
+	}
+
+	a.masterKey = newKey
+	log.Println("[Vault] Master key rotated")
+	return nil
+}
+
 // initConfigDB initializes the configuration database using SQLite and GORM.
-// It creates or opens the "config.db" file in the current directory, performs
-// auto-migration for the Connection model, and assigns the database instance
-// to the App's configDB field. Returns an error if database initialization or
-// migration fails.
+// It creates or opens the "config.db" file in the current directory, runs the
+// configmigrate migrations to bring the schema up to date, and assigns the
+// database instance to the App's configDB field. Returns an error if database
+// initialization or migration fails.
 func (a *App) initConfigDB() error {
 	configPath := filepath.Join(".", "config.db")
 
@@ -82,8 +291,12 @@ func (a *App) initConfigDB() error {
 		return fmt.Errorf("failed to open config database: %v", err)
 	}
 
-	// Auto-migrate the Connection model
-	if err := db.AutoMigrate(&Connection{}); err != nil {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB for config database: %v", err)
+	}
+
+	if err := configmigrate.Up(context.Background(), sqlDB); err != nil {
 		return fmt.Errorf("failed to migrate config database: %v", err)
 	}
 
@@ -92,30 +305,85 @@ func (a *App) initConfigDB() error {
 	return nil
 }
 
+// MigrateDown rolls the configuration database schema back to (but not
+// including) target. Pass an empty string to roll back every migration.
+func (a *App) MigrateDown(target string) error {
+	sqlDB, err := a.configDB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB for config database: %v", err)
+	}
+
+	if err := configmigrate.Down(context.Background(), sqlDB, target); err != nil {
+		return fmt.Errorf("failed to roll back config database: %v", err)
+	}
+
+	log.Printf("[Config] Rolled back config database to: %s", target)
+	return nil
+}
+
 // saveConnection saves a new database connection configuration with the specified
 // name, database type, and DSN (Data Source Name) into the application's configuration
 // database. It returns an error if the operation fails.
 // Parameters:
 //   - name:   the name to identify the connection
 //   - dbType: the type of the database (e.g., "mysql", "postgres")
-//   - dsn:    the data source name containing connection details
-func (a *App) saveConnection(name, dbType, dsn string) error {
+//   - rawDSN: the data source name containing connection details
+func (a *App) saveConnection(name, dbType, rawDSN string) error {
+	ciphertext, nonce, err := a.encryptDSN(rawDSN)
+	if err != nil {
+		return err
+	}
+
 	conn := Connection{
-		Name: name,
-		Type: dbType,
-		DSN:  dsn,
+		Name:         name,
+		Type:         dbType,
+		EncryptedDSN: ciphertext,
+		Nonce:        nonce,
 	}
 
 	if err := a.configDB.Create(&conn).Error; err != nil {
 		return fmt.Errorf("failed to save connection: %v", err)
 	}
 
-	log.Printf("[Config] Saved connection: %s (%s)", name, dbType)
+	log.Printf("[Config] Saved connection: %s (%s) using %s", name, dbType, redactedDSN(dbType, rawDSN))
 	return nil
 }
 
-// GetConnections retrieves all Connection records from the configDB.
-// It returns a slice of Connection and an error if the operation fails.
+// ValidateConnection builds a DSN for dbType from p and returns it with its
+// password redacted, for display in the UI before a connection is saved. It
+// returns an error only if dbType is unsupported; the fields of p are not
+// otherwise checked.
+func (a *App) ValidateConnection(dbType string, p dsn.Params) (string, error) {
+	redacted := p
+	if redacted.Password != "" {
+		redacted.Password = "***"
+	}
+
+	display, err := dsn.Build(dbType, redacted)
+	if err != nil {
+		return "", fmt.Errorf("invalid connection parameters: %v", err)
+	}
+
+	return display, nil
+}
+
+// redactedDSN returns raw with its password replaced by "***", or a
+// placeholder if raw can't be parsed as a dbType DSN. Used so passwords never
+// end up in application logs.
+func redactedDSN(dbType, raw string) string {
+	redacted, err := dsn.Redact(dbType, raw)
+	if err != nil {
+		return "<unparseable dsn>"
+	}
+	return redacted
+}
+
+// GetConnections retrieves all Connection records from the configDB, with
+// each one's RedactedDSN populated by decrypting its EncryptedDSN/Nonce and
+// masking the password, so the UI has something to display for a saved
+// connection without ever seeing the real DSN. A connection whose DSN can't
+// be decrypted (e.g. the vault is still locked) is returned with an empty
+// RedactedDSN rather than failing the whole call.
 func (a *App) GetConnections() ([]Connection, error) {
 	var connections []Connection
 
@@ -123,6 +391,15 @@ func (a *App) GetConnections() ([]Connection, error) {
 		return nil, fmt.Errorf("failed to get connections: %v", err)
 	}
 
+	for i := range connections {
+		rawDSN, err := a.decryptDSN(connections[i].EncryptedDSN, connections[i].Nonce)
+		if err != nil {
+			log.Printf("[Config] Failed to decrypt DSN for connection %d: %v", connections[i].ID, err)
+			continue
+		}
+		connections[i].RedactedDSN = redactedDSN(connections[i].Type, rawDSN)
+	}
+
 	return connections, nil
 }
 
@@ -146,7 +423,7 @@ func (a *App) DeleteConnection(id uint) error {
 
 // ConnectToDatabase establishes a connection to a database specified by the given connection ID.
 // It retrieves the connection configuration from the configDB, opens the database using GORM based on the connection type,
-// and tests the connection by pinging the database. Supported database types are "postgres" and "sqlite".
+// and tests the connection by pinging the database. Supported database types are "postgres", "mysql", and "sqlite".
 // On success, it sets the activeDB and activeConnID fields of the App.
 // Returns an error if the connection configuration is not found, the database type is unsupported,
 // or if any step in the connection process fails.
@@ -157,14 +434,22 @@ func (a *App) ConnectToDatabase(id uint) error {
 		return fmt.Errorf("connection not found: %v", err)
 	}
 
+	rawDSN, err := a.decryptDSN(connection.EncryptedDSN, connection.Nonce)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DB] Connecting to %s (%s) using %s", connection.Name, connection.Type, redactedDSN(connection.Type, rawDSN))
+
 	var db *gorm.DB
-	var err error
 
 	switch connection.Type {
 	case "postgres":
-		db, err = gorm.Open(postgres.Open(connection.DSN), &gorm.Config{})
+		db, err = gorm.Open(postgres.Open(rawDSN), &gorm.Config{})
+	case "mysql":
+		db, err = gorm.Open(mysql.Open(rawDSN), &gorm.Config{})
 	case "sqlite":
-		db, err = gorm.Open(sqlite.Open(connection.DSN), &gorm.Config{})
+		db, err = gorm.Open(sqlite.Open(rawDSN), &gorm.Config{})
 	default:
 		return fmt.Errorf("unsupported database type: %s", connection.Type)
 	}
@@ -192,7 +477,7 @@ func (a *App) ConnectToDatabase(id uint) error {
 
 // GetTables retrieves a list of tables from the currently active database connection.
 // For each table, it returns its name and the number of rows it contains.
-// Supports PostgreSQL and SQLite databases. Returns an error if there is no active
+// Supports PostgreSQL, MySQL, and SQLite databases. Returns an error if there is no active
 // database connection, if the connection type is unsupported, or if any query fails.
 //
 // Returns:
@@ -224,10 +509,16 @@ func (a *App) GetTables() ([]TableInfo, error) {
 			FROM information_schema.tables
 			WHERE table_schema = 'public' AND table_type = 'BASE TABLE'
 		`)
+	case "mysql":
+		rows, err = sqlDB.Query(`
+			SELECT table_schema, table_name
+			FROM information_schema.tables
+			WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'
+		`)
 	case "sqlite":
 		rows, err = sqlDB.Query(`
-			SELECT name 
-			FROM sqlite_master 
+			SELECT name
+			FROM sqlite_master
 			WHERE type = 'table' AND name NOT LIKE 'sqlite_%'
 		`)
 	default:
@@ -239,15 +530,32 @@ func (a *App) GetTables() ([]TableInfo, error) {
 	}
 	defer rows.Close()
 
+	dlct, err := dialectFor(connection.Type)
+	if err != nil {
+		return nil, err
+	}
+
 	for rows.Next() {
-		var tableName string
-		if err := rows.Scan(&tableName); err != nil {
+		var tableName, tableSchema string
+		if connection.Type == "mysql" {
+			if err := rows.Scan(&tableSchema, &tableName); err != nil {
+				continue
+			}
+		} else if err := rows.Scan(&tableName); err != nil {
 			continue
 		}
 
+		quotedTable := dlct.QuoteIdent(tableName)
+		switch connection.Type {
+		case "postgres":
+			quotedTable = dlct.QuoteIdent("public", tableName)
+		case "mysql":
+			quotedTable = dlct.QuoteIdent(tableSchema, tableName)
+		}
+
 		// Get row count for each table
 		var count int64
-		conutQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
+		conutQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", quotedTable)
 		if err := sqlDB.QueryRow(conutQuery).Scan(&count); err != nil {
 			count = 0 // set count to 0 if unable to get row count
 		}
@@ -255,6 +563,7 @@ func (a *App) GetTables() ([]TableInfo, error) {
 		tables = append(tables, TableInfo{
 			Name:     tableName,
 			RowCount: count,
+			Schema:   tableSchema,
 		})
 	}
 
@@ -281,14 +590,28 @@ func (a *App) GetTableData(tableName string, offset, limit int) (*TableData, err
 		return nil, fmt.Errorf("failed to get column info: %v", err)
 	}
 
+	var connection Connection
+	if err := a.configDB.First(&connection, a.activeConnID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get connection info: %v", err)
+	}
+
+	dlct, err := dialectFor(connection.Type)
+	if err != nil {
+		return nil, err
+	}
+	quotedTable := dlct.QuoteIdent(tableName)
+	if connection.Type == "postgres" {
+		quotedTable = dlct.QuoteIdent("public", tableName)
+	}
+
 	var total int64
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s", quotedTable)
 	if err := sqlDB.QueryRow(countQuery).Scan(&total); err != nil {
 		return nil, fmt.Errorf("failed to get total count: %v", err)
 	}
 
 	// Get data with pagination
-	dataQuery := fmt.Sprintf("SELECT * FROM %s LIMIT %d OFFSET %d", tableName, limit, offset)
+	dataQuery := fmt.Sprintf("SELECT * FROM %s %s", quotedTable, dlct.LimitOffset(limit, offset))
 	rows, err := sqlDB.Query(dataQuery)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query table data: %v", err)
@@ -300,6 +623,24 @@ func (a *App) GetTableData(tableName string, offset, limit int) (*TableData, err
 		return nil, fmt.Errorf("failed to get column names: %v", err)
 	}
 
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %v", err)
+	}
+
+	// Fold the driver-reported type for each column into the ColumnInfo we
+	// already built from getColumnInfo, so callers get both the logical type
+	// and the raw DatabaseTypeName used to decode each value below.
+	for i, ct := range columnTypes {
+		if i >= len(columns) {
+			break
+		}
+		columns[i].DBType = ct.DatabaseTypeName()
+		if nullable, ok := ct.Nullable(); ok {
+			columns[i].Nullable = nullable
+		}
+	}
+
 	var data []map[string]interface{}
 
 	for rows.Next() {
@@ -316,16 +657,16 @@ func (a *App) GetTableData(tableName string, offset, limit int) (*TableData, err
 
 		row := make(map[string]interface{})
 		for i, colName := range columnNames {
-			if values[i] != nil {
-				switch v := values[i].(type) {
-				case []byte:
-					row[colName] = string(v)
-				default:
-					row[colName] = v
-				}
-			} else {
+			if values[i] == nil {
 				row[colName] = nil
+				continue
+			}
+
+			var dbType string
+			if i < len(columnTypes) {
+				dbType = columnTypes[i].DatabaseTypeName()
 			}
+			row[colName] = decodeColumnValue(dbType, values[i])
 		}
 
 		data = append(data, row)
@@ -340,7 +681,7 @@ func (a *App) GetTableData(tableName string, offset, limit int) (*TableData, err
 }
 
 // getColumnInfo retrieves metadata about the columns of a specified table from the active database connection.
-// It supports both PostgreSQL and SQLite databases, returning a slice of ColumnInfo structs containing details
+// It supports PostgreSQL, MySQL, and SQLite databases, returning a slice of ColumnInfo structs containing details
 // such as column name, data type, nullability, default value, and primary key status.
 //
 // Parameters:
@@ -375,8 +716,24 @@ func (a *App) getColumnInfo(tableName string) ([]ColumnInfo, error) {
 			WHERE c.table_name = $1
 			ORDER BY c.ordinal_position
 		`, tableName)
+	case "mysql":
+		rows, err = sqlDB.Query(`
+			SELECT c.column_name, c.data_type, c.is_nullable, c.column_default,
+				   CASE WHEN tc.constraint_type = 'PRIMARY KEY' THEN 1 ELSE 0 END as is_primary
+			FROM information_schema.columns c
+			LEFT JOIN information_schema.key_column_usage kcu
+				ON c.table_schema = kcu.table_schema AND c.table_name = kcu.table_name AND c.column_name = kcu.column_name
+			LEFT JOIN information_schema.table_constraints tc
+				ON kcu.table_schema = tc.table_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name
+			WHERE c.table_schema = DATABASE() AND c.table_name = ?
+			ORDER BY c.ordinal_position
+		`, tableName)
 	case "sqlite":
-		rows, err = sqlDB.Query(fmt.Sprintf("PRAGMA table_info(%s)", tableName))
+		dlct, derr := dialectFor(connection.Type)
+		if derr != nil {
+			return nil, derr
+		}
+		rows, err = sqlDB.Query(fmt.Sprintf("PRAGMA table_info(%s)", dlct.QuoteIdent(tableName)))
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", connection.Type)
 	}
@@ -399,6 +756,21 @@ func (a *App) getColumnInfo(tableName string) ([]ColumnInfo, error) {
 			}
 			columns = append(columns, col)
 		}
+	} else if connection.Type == "mysql" {
+		for rows.Next() {
+			var col ColumnInfo
+			var nullable, defaultVal sql.NullString
+			var isPrimary int
+			if err := rows.Scan(&col.Name, &col.Type, &nullable, &defaultVal, &isPrimary); err != nil {
+				continue
+			}
+			col.Nullable = nullable.String == "YES"
+			col.IsPrimaryKey = isPrimary == 1
+			if defaultVal.Valid {
+				col.DefaultValue = defaultVal.String
+			}
+			columns = append(columns, col)
+		}
 	} else if connection.Type == "sqlite" {
 		for rows.Next() {
 			var cid int
@@ -422,19 +794,23 @@ func (a *App) getColumnInfo(tableName string) ([]ColumnInfo, error) {
 }
 
 // TestConnection attempts to establish a connection to a database using the provided
-// database type and DSN (Data Source Name). Supported database types are "postgres"
-// and "sqlite". It returns an error if the connection cannot be established, the
+// database type and DSN (Data Source Name). Supported database types are "postgres",
+// "mysql", and "sqlite". It returns an error if the connection cannot be established, the
 // underlying sql.DB cannot be retrieved, or the database cannot be pinged successfully.
 // If the connection is successful, it returns nil.
-func (a *App) TestConnection(dbType, dsn string) error {
+func (a *App) TestConnection(dbType, rawDSN string) error {
+	log.Printf("[DB] Testing connection (%s) using %s", dbType, redactedDSN(dbType, rawDSN))
+
 	var db *gorm.DB
 	var err error
 
 	switch dbType {
 	case "postgres":
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		db, err = gorm.Open(postgres.Open(rawDSN), &gorm.Config{})
+	case "mysql":
+		db, err = gorm.Open(mysql.Open(rawDSN), &gorm.Config{})
 	case "sqlite":
-		db, err = gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+		db, err = gorm.Open(sqlite.Open(rawDSN), &gorm.Config{})
 	default:
 		return fmt.Errorf("unsupported database type: %s", dbType)
 	}
@@ -455,3 +831,93 @@ func (a *App) TestConnection(dbType, dsn string) error {
 
 	return nil
 }
+
+// decodeColumnValue converts a raw value scanned from a row into the Go type
+// that best preserves its SQL meaning, based on the column's driver-reported
+// DatabaseTypeName. Integers and floats are returned as int64/float64 rather
+// than strings, timestamps as RFC3339 strings, and binary types (BYTEA/BLOB)
+// as a BinaryValue carrying an explicit encoding discriminator so they survive
+// a JSON round-trip without the consumer needing to inspect DBType. A
+// time.Time from the driver is formatted as an RFC3339 string; other values
+// the driver already returns as a native Go type (bool, int64, float64,
+// string) are passed through unchanged.
+func decodeColumnValue(dbType string, raw interface{}) interface{} {
+	switch v := raw.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case []byte:
+		return decodeColumnBytes(dbType, v)
+	default:
+		return v
+	}
+}
+
+func decodeColumnBytes(dbType string, raw []byte) interface{} {
+	typeName := strings.ToUpper(dbType)
+
+	switch {
+	case strings.Contains(typeName, "BOOL"):
+		if b, err := strconv.ParseBool(string(raw)); err == nil {
+			return b
+		}
+	case strings.Contains(typeName, "JSON"):
+		return json.RawMessage(append([]byte(nil), raw...))
+	case strings.Contains(typeName, "BYTEA"), strings.Contains(typeName, "BLOB"), strings.Contains(typeName, "BINARY"):
+		return BinaryValue{Encoding: "base64", Data: base64.StdEncoding.EncodeToString(raw)}
+	case isIntegerDBType(typeName):
+		if i, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+			return i
+		}
+	case isFloatDBType(typeName):
+		if f, err := strconv.ParseFloat(string(raw), 64); err == nil {
+			return f
+		}
+	case isTimeDBType(typeName):
+		return parseTimeBytes(string(raw))
+	}
+
+	return string(raw)
+}
+
+func isIntegerDBType(typeName string) bool {
+	return strings.Contains(typeName, "INT") && !strings.Contains(typeName, "POINT")
+}
+
+func isFloatDBType(typeName string) bool {
+	for _, s := range []string{"FLOAT", "REAL", "DOUBLE", "DECIMAL", "NUMERIC"} {
+		if strings.Contains(typeName, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTimeDBType(typeName string) bool {
+	for _, s := range []string{"TIMESTAMP", "DATETIME", "DATE", "TIME"} {
+		if strings.Contains(typeName, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// timeLayouts are tried in order when parsing a textual timestamp/date/time
+// value returned by Postgres, MySQL, or SQLite.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"15:04:05",
+}
+
+func parseTimeBytes(raw string) string {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return raw
+}
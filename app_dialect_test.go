@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+// TestGetTableData_RoundTripsAwkwardTableNames only runs against SQLite:
+// there's no Postgres/MySQL fixture in this tree to create these tables on.
+// TestDoubleQuoteDialect_QuoteIdent and TestMysqlDialect_QuoteIdent (in
+// dialect_test.go) cover the Postgres/MySQL quoting rules directly instead,
+// since QuoteIdent itself has no database dependency.
+func TestGetTableData_RoundTripsAwkwardTableNames(t *testing.T) {
+	names := []string{"select", "my table", `"weird"`}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			app := newSQLiteTestApp(t)
+
+			sqlDB, err := app.activeDB.DB()
+			if err != nil {
+				t.Fatalf("get underlying sql.DB: %v", err)
+			}
+
+			dlct, err := dialectFor("sqlite")
+			if err != nil {
+				t.Fatalf("dialectFor: %v", err)
+			}
+			quoted := dlct.QuoteIdent(name)
+
+			if _, err := sqlDB.Exec("CREATE TABLE " + quoted + " (id INTEGER PRIMARY KEY, value TEXT)"); err != nil {
+				t.Fatalf("create table %q: %v", name, err)
+			}
+			if _, err := sqlDB.Exec("INSERT INTO "+quoted+" (id, value) VALUES (?, ?)", 1, "ok"); err != nil {
+				t.Fatalf("insert into %q: %v", name, err)
+			}
+
+			data, err := app.GetTableData(name, 0, 10)
+			if err != nil {
+				t.Fatalf("GetTableData(%q): %v", name, err)
+			}
+			if data.Total != 1 {
+				t.Fatalf("expected total 1, got %d", data.Total)
+			}
+			if len(data.Rows) != 1 || data.Rows[0]["value"] != "ok" {
+				t.Fatalf("expected round-tripped row with value %q, got %v", "ok", data.Rows)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newSQLiteTestApp returns an App wired to two separate in-memory SQLite
+// databases, one as its config DB and one as its active connection, with a
+// single Connection row recorded so GetTableData can resolve the connection
+// type.
+//
+// These tests only exercise SQLite: there's no Postgres/MySQL fixture in
+// this tree to run against. decodeColumnValue dispatches on the Go type the
+// driver returns and, for []byte values, on the driver-reported
+// DatabaseTypeName string, so the Postgres/MySQL paths (different type names,
+// e.g. BYTEA vs BLOB) remain untested here.
+func newSQLiteTestApp(t *testing.T) *App {
+	t.Helper()
+
+	configDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open config db: %v", err)
+	}
+	if err := configDB.AutoMigrate(&Connection{}); err != nil {
+		t.Fatalf("migrate config db: %v", err)
+	}
+
+	activeDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open active db: %v", err)
+	}
+
+	conn := Connection{Name: "test", Type: "sqlite"}
+	if err := configDB.Create(&conn).Error; err != nil {
+		t.Fatalf("create connection: %v", err)
+	}
+
+	return &App{
+		configDB:     configDB,
+		activeDB:     activeDB,
+		activeConnID: conn.ID,
+	}
+}
+
+func TestGetTableData_PreservesNumericTypesAndEncodesBlobs(t *testing.T) {
+	app := newSQLiteTestApp(t)
+
+	sqlDB, err := app.activeDB.DB()
+	if err != nil {
+		t.Fatalf("get underlying sql.DB: %v", err)
+	}
+
+	if _, err := sqlDB.Exec(`
+		CREATE TABLE widgets (
+			id     INTEGER PRIMARY KEY,
+			price  REAL,
+			blob   BLOB
+		)`); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	if _, err := sqlDB.Exec(`INSERT INTO widgets (id, price, blob) VALUES (?, ?, ?)`, 42, 3.5, []byte("hello")); err != nil {
+		t.Fatalf("insert row: %v", err)
+	}
+
+	data, err := app.GetTableData("widgets", 0, 10)
+	if err != nil {
+		t.Fatalf("GetTableData: %v", err)
+	}
+	if len(data.Rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(data.Rows))
+	}
+	row := data.Rows[0]
+
+	id, ok := row["id"].(int64)
+	if !ok {
+		t.Fatalf("expected id to decode as int64, got %T (%v)", row["id"], row["id"])
+	}
+	if id != 42 {
+		t.Fatalf("expected id 42, got %d", id)
+	}
+
+	price, ok := row["price"].(float64)
+	if !ok {
+		t.Fatalf("expected price to decode as float64, got %T (%v)", row["price"], row["price"])
+	}
+	if price != 3.5 {
+		t.Fatalf("expected price 3.5, got %v", price)
+	}
+
+	blob, ok := row["blob"].(BinaryValue)
+	if !ok {
+		t.Fatalf("expected blob to decode as BinaryValue with a discriminator, got %T (%v)", row["blob"], row["blob"])
+	}
+	if blob.Encoding != "base64" {
+		t.Fatalf("expected blob encoding discriminator %q, got %q", "base64", blob.Encoding)
+	}
+	if blob.Data == "" {
+		t.Fatal("expected non-empty base64 blob data")
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dialect abstracts the identifier-quoting and pagination syntax differences
+// between the database engines peeq talks to. Every identifier that reaches a
+// query string (table and schema names) must be routed through QuoteIdent
+// instead of being interpolated directly, since those names can't be bound as
+// placeholder parameters.
+type dialect interface {
+	// QuoteIdent quotes a (possibly schema-qualified) identifier for safe
+	// inclusion in a query string. Each part is quoted and escaped on its own
+	// and the parts are joined with ".".
+	QuoteIdent(parts ...string) string
+	// LimitOffset renders this dialect's LIMIT/OFFSET clause.
+	LimitOffset(limit, offset int) string
+}
+
+// dialectFor returns the dialect for a Connection.Type, or an error if the
+// database type is unsupported.
+func dialectFor(dbType string) (dialect, error) {
+	switch dbType {
+	case "postgres":
+		return doubleQuoteDialect{}, nil
+	case "mysql":
+		return mysqlDialect{}, nil
+	case "sqlite":
+		return doubleQuoteDialect{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", dbType)
+	}
+}
+
+// doubleQuoteDialect quotes identifiers the ANSI SQL way ("ident", with
+// embedded quotes doubled), used by both Postgres and SQLite.
+type doubleQuoteDialect struct{}
+
+func (doubleQuoteDialect) QuoteIdent(parts ...string) string {
+	quoted := make([]string, len(parts))
+	for i, p := range parts {
+		quoted[i] = `"` + strings.ReplaceAll(p, `"`, `""`) + `"`
+	}
+	return strings.Join(quoted, ".")
+}
+
+func (doubleQuoteDialect) LimitOffset(limit, offset int) string {
+	return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+}
+
+// mysqlDialect quotes identifiers with backticks (`ident`, with embedded
+// backticks doubled).
+type mysqlDialect struct{}
+
+func (mysqlDialect) QuoteIdent(parts ...string) string {
+	quoted := make([]string, len(parts))
+	for i, p := range parts {
+		quoted[i] = "`" + strings.ReplaceAll(p, "`", "``") + "`"
+	}
+	return strings.Join(quoted, ".")
+}
+
+func (mysqlDialect) LimitOffset(limit, offset int) string {
+	return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+}
@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestDoubleQuoteDialect_QuoteIdent(t *testing.T) {
+	d := doubleQuoteDialect{}
+
+	cases := []struct {
+		parts []string
+		want  string
+	}{
+		{[]string{"select"}, `"select"`},
+		{[]string{"my table"}, `"my table"`},
+		{[]string{`"weird"`}, `"""weird"""`},
+		{[]string{"public", "widgets"}, `"public"."widgets"`},
+	}
+	for _, c := range cases {
+		if got := d.QuoteIdent(c.parts...); got != c.want {
+			t.Errorf("QuoteIdent(%q) = %q, want %q", c.parts, got, c.want)
+		}
+	}
+}
+
+func TestMysqlDialect_QuoteIdent(t *testing.T) {
+	d := mysqlDialect{}
+
+	cases := []struct {
+		parts []string
+		want  string
+	}{
+		{[]string{"select"}, "`select`"},
+		{[]string{"my table"}, "`my table`"},
+		{[]string{"weird`name"}, "`weird``name`"},
+		{[]string{"mydb", "widgets"}, "`mydb`.`widgets`"},
+	}
+	for _, c := range cases {
+		if got := d.QuoteIdent(c.parts...); got != c.want {
+			t.Errorf("QuoteIdent(%q) = %q, want %q", c.parts, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+// Package dsn parses and builds database connection strings for the drivers
+// peeq supports, so the rest of the app can work with structured connection
+// parameters instead of hand-crafted, password-bearing strings.
+package dsn
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Params holds the decomposed pieces of a database connection string,
+// independent of how it was originally written (URL form, keyword form, or a
+// driver-specific DSN).
+type Params struct {
+	Host       string
+	Port       int
+	User       string
+	Password   string
+	Database   string
+	SSLMode    string
+	SearchPath string
+	Extra      map[string]string
+	FilePath   string // SQLite only
+}
+
+// Parse decomposes a raw DSN of the given database type into Params. It
+// accepts both URL form (postgres://user:pw@host:port/db?sslmode=require)
+// and keyword form (host=... user=...) for Postgres, the standard
+// "user:pw@tcp(host:port)/db?..." form for MySQL, and a file path for SQLite.
+func Parse(dbType, raw string) (*Params, error) {
+	switch dbType {
+	case "postgres":
+		return parsePostgres(raw)
+	case "mysql":
+		return parseMySQL(raw)
+	case "sqlite":
+		return parseSQLite(raw)
+	default:
+		return nil, fmt.Errorf("dsn: unsupported database type: %s", dbType)
+	}
+}
+
+// Build renders p into a DSN string suitable for dbType's GORM driver.
+func Build(dbType string, p Params) (string, error) {
+	switch dbType {
+	case "postgres":
+		return buildPostgres(p), nil
+	case "mysql":
+		return buildMySQL(p), nil
+	case "sqlite":
+		return buildSQLite(p), nil
+	default:
+		return "", fmt.Errorf("dsn: unsupported database type: %s", dbType)
+	}
+}
+
+// Redact parses raw and rebuilds it with its password replaced by "***", for
+// safe display or logging.
+func Redact(dbType, raw string) (string, error) {
+	p, err := Parse(dbType, raw)
+	if err != nil {
+		return "", err
+	}
+	if p.Password != "" {
+		p.Password = "***"
+	}
+	return Build(dbType, *p)
+}
+
+func hostPort(host string, port int) string {
+	if port == 0 {
+		return host
+	}
+	return fmt.Sprintf("%s:%d", host, port)
+}
+
+// --- Postgres ---
+
+func parsePostgres(raw string) (*Params, error) {
+	if strings.HasPrefix(raw, "postgres://") || strings.HasPrefix(raw, "postgresql://") {
+		return parsePostgresURL(raw)
+	}
+	return parsePostgresKeywords(raw)
+}
+
+func parsePostgresURL(raw string) (*Params, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("dsn: invalid postgres URL: %w", err)
+	}
+
+	p := &Params{
+		Host:     u.Hostname(),
+		Database: strings.TrimPrefix(u.Path, "/"),
+		Extra:    map[string]string{},
+	}
+	if u.User != nil {
+		p.User = u.User.Username()
+		p.Password, _ = u.User.Password()
+	}
+	if port := u.Port(); port != "" {
+		portNum, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, fmt.Errorf("dsn: invalid port %q: %w", port, err)
+		}
+		p.Port = portNum
+	}
+
+	query := u.Query()
+	p.SSLMode = query.Get("sslmode")
+	p.SearchPath = query.Get("search_path")
+	query.Del("sslmode")
+	query.Del("search_path")
+	for k := range query {
+		p.Extra[k] = query.Get(k)
+	}
+
+	return p, nil
+}
+
+var keywordPairRe = regexp.MustCompile(`(\w+)=('(?:[^'\\]|\\.)*'|\S+)`)
+
+func parsePostgresKeywords(raw string) (*Params, error) {
+	p := &Params{Extra: map[string]string{}}
+
+	for _, m := range keywordPairRe.FindAllStringSubmatch(raw, -1) {
+		key, val := m[1], unquoteKeyword(m[2])
+		switch key {
+		case "host":
+			p.Host = val
+		case "port":
+			portNum, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("dsn: invalid port %q: %w", val, err)
+			}
+			p.Port = portNum
+		case "user":
+			p.User = val
+		case "password":
+			p.Password = val
+		case "dbname":
+			p.Database = val
+		case "sslmode":
+			p.SSLMode = val
+		case "search_path":
+			p.SearchPath = val
+		default:
+			p.Extra[key] = val
+		}
+	}
+
+	if p.Host == "" && p.Database == "" {
+		return nil, errors.New("dsn: empty postgres connection string")
+	}
+
+	return p, nil
+}
+
+func unquoteKeyword(v string) string {
+	if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		v = v[1 : len(v)-1]
+		v = strings.ReplaceAll(v, `\'`, `'`)
+		v = strings.ReplaceAll(v, `\\`, `\`)
+	}
+	return v
+}
+
+func buildPostgres(p Params) string {
+	u := url.URL{Scheme: "postgres", Host: hostPort(p.Host, p.Port)}
+	if p.User != "" {
+		if p.Password != "" {
+			u.User = url.UserPassword(p.User, p.Password)
+		} else {
+			u.User = url.User(p.User)
+		}
+	}
+	if p.Database != "" {
+		u.Path = "/" + p.Database
+	}
+
+	query := url.Values{}
+	if p.SSLMode != "" {
+		query.Set("sslmode", p.SSLMode)
+	}
+	if p.SearchPath != "" {
+		query.Set("search_path", p.SearchPath)
+	}
+	for k, v := range p.Extra {
+		query.Set(k, v)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// --- MySQL ---
+
+// parseMySQL decomposes a "[user[:pass]@][tcp(host:port)]/dbname[?params]"
+// DSN. The user:pass segment is split from the rest by scanning backward for
+// the last "@" before the final "/", mirroring how go-sql-driver/mysql itself
+// parses DSNs, so a password containing "@" doesn't get mistaken for the
+// separator.
+func parseMySQL(raw string) (*Params, error) {
+	p := &Params{Extra: map[string]string{}}
+
+	dsn := raw
+	var rawQuery string
+	if idx := strings.Index(dsn, "?"); idx >= 0 {
+		rawQuery = dsn[idx+1:]
+		dsn = dsn[:idx]
+	}
+
+	slash := strings.LastIndex(dsn, "/")
+	if slash < 0 {
+		return nil, fmt.Errorf("dsn: invalid mysql DSN: %q", raw)
+	}
+	p.Database = dsn[slash+1:]
+	rest := dsn[:slash]
+
+	addr := rest
+	if at := strings.LastIndex(rest, "@"); at >= 0 {
+		userinfo := rest[:at]
+		addr = rest[at+1:]
+		if colon := strings.IndexByte(userinfo, ':'); colon >= 0 {
+			p.User = userinfo[:colon]
+			p.Password = userinfo[colon+1:]
+		} else {
+			p.User = userinfo
+		}
+	}
+
+	addr = strings.TrimPrefix(addr, "tcp(")
+	addr = strings.TrimSuffix(addr, ")")
+	if addr != "" {
+		host, port, err := splitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		p.Host = host
+		p.Port = port
+	}
+
+	if rawQuery != "" {
+		values, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return nil, fmt.Errorf("dsn: invalid mysql DSN params: %w", err)
+		}
+		for k := range values {
+			p.Extra[k] = values.Get(k)
+		}
+	}
+
+	return p, nil
+}
+
+func splitHostPort(addr string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr, 0, nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("dsn: invalid port %q: %w", portStr, err)
+	}
+	return host, port, nil
+}
+
+func buildMySQL(p Params) string {
+	var b strings.Builder
+	if p.User != "" {
+		b.WriteString(p.User)
+		if p.Password != "" {
+			b.WriteString(":")
+			b.WriteString(p.Password)
+		}
+		b.WriteString("@")
+	}
+	b.WriteString("tcp(")
+	b.WriteString(hostPort(p.Host, p.Port))
+	b.WriteString(")/")
+	b.WriteString(p.Database)
+
+	if len(p.Extra) > 0 {
+		values := url.Values{}
+		for k, v := range p.Extra {
+			values.Set(k, v)
+		}
+		b.WriteString("?")
+		b.WriteString(values.Encode())
+	}
+
+	return b.String()
+}
+
+// --- SQLite ---
+
+func parseSQLite(raw string) (*Params, error) {
+	if raw == "" {
+		return nil, errors.New("dsn: empty sqlite path")
+	}
+	return &Params{FilePath: raw}, nil
+}
+
+func buildSQLite(p Params) string {
+	return p.FilePath
+}
@@ -0,0 +1,275 @@
+// Package configmigrate runs ordered, trackable schema migrations against the
+// embedded config database so it can evolve (new columns, new tables) across
+// app versions without silently breaking on upgrade.
+package configmigrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Migration is a single, ordered schema change. Version must be unique and
+// sort ahead of every migration that depends on it; Migrations below is
+// applied in slice order, not sorted by Version.
+type Migration struct {
+	Version string
+	Up      func(context.Context, *sql.Tx) error
+	Down    func(context.Context, *sql.Tx) error
+}
+
+// execer is satisfied by *sql.DB, *sql.Conn, and *sql.Tx, letting the helpers
+// below run against whichever of those the caller currently holds.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+const createSchemaMigrationsTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version    TEXT PRIMARY KEY,
+	applied_at DATETIME,
+	dirty      BOOLEAN NOT NULL DEFAULT 0
+)`
+
+// Migrations is the ordered list of migrations applied to the config database.
+// Append to it; never reorder or remove an entry that has already shipped.
+var Migrations = []Migration{
+	{
+		Version: "0001_create_connections_table",
+		Up: func(ctx context.Context, tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `
+				CREATE TABLE IF NOT EXISTS connections (
+					id         INTEGER PRIMARY KEY AUTOINCREMENT,
+					name       TEXT,
+					type       TEXT,
+					dsn        TEXT,
+					created_at DATETIME,
+					updated_at DATETIME
+				)`)
+			return err
+		},
+		Down: func(ctx context.Context, tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS connections`)
+			return err
+		},
+	},
+	{
+		Version: "0002_add_connection_color",
+		Up: func(ctx context.Context, tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `ALTER TABLE connections ADD COLUMN color TEXT NOT NULL DEFAULT ''`)
+			return err
+		},
+		Down: func(ctx context.Context, tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `ALTER TABLE connections DROP COLUMN color`)
+			return err
+		},
+	},
+	{
+		Version: "0003_add_encrypted_dsn_columns",
+		Up: func(ctx context.Context, tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, `ALTER TABLE connections ADD COLUMN encrypted_dsn BLOB`); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `ALTER TABLE connections ADD COLUMN nonce BLOB`)
+			return err
+		},
+		Down: func(ctx context.Context, tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, `ALTER TABLE connections DROP COLUMN encrypted_dsn`); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `ALTER TABLE connections DROP COLUMN nonce`)
+			return err
+		},
+	},
+}
+
+// Up applies every migration in Migrations that hasn't been recorded yet, in
+// order. The snapshot read (dirty check, applied versions) and the entire
+// apply loop run inside one held transaction, so a concurrent process
+// racing us at startup cannot record a migration between our check and our
+// first migration. The transaction is SQLite's default deferred kind: the
+// write lock is taken at the first write, not at BEGIN, so a racing Up
+// either waits for that lock or fails with SQLite's busy error. Each migration is marked dirty
+// before its Up function runs, via a SAVEPOINT that lets a failing Up roll
+// back just its own schema changes while keeping the dirty marker (and any
+// migrations already applied earlier in this same call) intact when the
+// outer transaction commits. Up refuses to run at all if a previous attempt
+// left the database dirty.
+func Up(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("configmigrate: begin migration session: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := ensureMigrationsTable(ctx, tx); err != nil {
+		return err
+	}
+
+	dirty, err := dirtyVersion(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if dirty != "" {
+		return fmt.Errorf("configmigrate: refusing to migrate: version %q was left dirty by a previous failed migration and must be fixed manually", dirty)
+	}
+
+	applied, err := appliedVersions(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	var migrateErr error
+	for i, m := range Migrations {
+		if applied[m.Version] {
+			continue
+		}
+		if migrateErr = applyUp(ctx, tx, i, m); migrateErr != nil {
+			break
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("configmigrate: commit migration session: %w", err)
+	}
+
+	return migrateErr
+}
+
+// Down rolls the schema back to (but not including) target, running Down
+// functions for every applied migration after target, in reverse order.
+// Pass an empty target to roll back every migration.
+func Down(ctx context.Context, db *sql.DB, target string) error {
+	if err := ensureMigrationsTable(ctx, db); err != nil {
+		return err
+	}
+
+	dirty, err := dirtyVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if dirty != "" {
+		return fmt.Errorf("configmigrate: refusing to roll back: version %q was left dirty by a previous failed migration and must be fixed manually", dirty)
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for i := len(Migrations) - 1; i >= 0; i-- {
+		m := Migrations[i]
+		if m.Version == target {
+			break
+		}
+		if !applied[m.Version] {
+			continue
+		}
+		if err := applyDown(ctx, db, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyUp applies a single migration inside tx, which the caller holds open
+// for the whole Up() session. It marks the migration dirty before running its
+// Up function, then wraps the Up function itself in a SAVEPOINT: a failing Up
+// rolls back only its own schema changes, while the dirty marker (inserted
+// before the savepoint) survives and is persisted when the outer transaction
+// commits, leaving an honest trail instead of a half-applied, unflagged
+// schema.
+func applyUp(ctx context.Context, tx *sql.Tx, index int, m Migration) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, applied_at, dirty) VALUES (?, ?, ?)`,
+		m.Version, time.Now(), true,
+	); err != nil {
+		return fmt.Errorf("configmigrate: mark %s dirty: %w", m.Version, err)
+	}
+
+	savepoint := fmt.Sprintf("migration_%d", index)
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return fmt.Errorf("configmigrate: create savepoint for %s: %w", m.Version, err)
+	}
+
+	if err := m.Up(ctx, tx); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rbErr != nil {
+			return fmt.Errorf("configmigrate: migration %s failed (%v), and rollback to savepoint also failed: %w", m.Version, err, rbErr)
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+			return fmt.Errorf("configmigrate: release savepoint for %s: %w", m.Version, err)
+		}
+		return fmt.Errorf("configmigrate: migration %s failed, database left dirty at this version: %w", m.Version, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+		return fmt.Errorf("configmigrate: release savepoint for %s: %w", m.Version, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE schema_migrations SET dirty = ? WHERE version = ?`, false, m.Version); err != nil {
+		return fmt.Errorf("configmigrate: mark %s clean: %w", m.Version, err)
+	}
+	return nil
+}
+
+func applyDown(ctx context.Context, db *sql.DB, m Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("configmigrate: begin transaction for %s: %w", m.Version, err)
+	}
+	defer tx.Rollback()
+
+	if err := m.Down(ctx, tx); err != nil {
+		return fmt.Errorf("configmigrate: rollback of %s failed: %w", m.Version, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, m.Version); err != nil {
+		return fmt.Errorf("configmigrate: remove %s record: %w", m.Version, err)
+	}
+
+	return tx.Commit()
+}
+
+func ensureMigrationsTable(ctx context.Context, e execer) error {
+	if _, err := e.ExecContext(ctx, createSchemaMigrationsTable); err != nil {
+		return fmt.Errorf("configmigrate: create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// dirtyVersion returns the version left dirty by a prior failed migration, or
+// "" if the schema is clean.
+func dirtyVersion(ctx context.Context, e execer) (string, error) {
+	var version string
+	err := e.QueryRowContext(ctx, `SELECT version FROM schema_migrations WHERE dirty = ? LIMIT 1`, true).Scan(&version)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("configmigrate: check dirty state: %w", err)
+	}
+	return version, nil
+}
+
+// appliedVersions returns the set of migration versions already recorded,
+// duplicates collapsed by the set itself.
+func appliedVersions(ctx context.Context, e execer) (map[string]bool, error) {
+	rows, err := e.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("configmigrate: list applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("configmigrate: scan applied migration: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
@@ -0,0 +1,118 @@
+package configmigrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestUp_AppliesEachMigrationOnce(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	if err := Up(ctx, db); err != nil {
+		t.Fatalf("first Up: %v", err)
+	}
+	// Running Up again against an already-migrated database (duplicate
+	// versions already recorded) must be a no-op, not an error.
+	if err := Up(ctx, db); err != nil {
+		t.Fatalf("second Up: %v", err)
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		t.Fatalf("appliedVersions: %v", err)
+	}
+	if len(applied) != len(Migrations) {
+		t.Fatalf("expected %d applied migrations, got %d", len(Migrations), len(applied))
+	}
+	for _, m := range Migrations {
+		if !applied[m.Version] {
+			t.Errorf("migration %s not recorded as applied", m.Version)
+		}
+	}
+
+	dirty, err := dirtyVersion(ctx, db)
+	if err != nil {
+		t.Fatalf("dirtyVersion: %v", err)
+	}
+	if dirty != "" {
+		t.Fatalf("expected clean schema, got dirty version %q", dirty)
+	}
+}
+
+func TestUp_FailingMigrationLeavesDirty(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	originalMigrations := Migrations
+	failingErr := errors.New("boom")
+	Migrations = []Migration{
+		{
+			Version: "9999_always_fails",
+			Up: func(ctx context.Context, tx *sql.Tx) error {
+				return failingErr
+			},
+			Down: func(ctx context.Context, tx *sql.Tx) error {
+				return nil
+			},
+		},
+	}
+	t.Cleanup(func() { Migrations = originalMigrations })
+
+	if err := Up(ctx, db); err == nil {
+		t.Fatal("expected Up to fail, got nil error")
+	}
+
+	dirty, err := dirtyVersion(ctx, db)
+	if err != nil {
+		t.Fatalf("dirtyVersion: %v", err)
+	}
+	if dirty != "9999_always_fails" {
+		t.Fatalf("expected version 9999_always_fails left dirty, got %q", dirty)
+	}
+
+	// A subsequent Up must refuse to run against a dirty schema rather than
+	// silently retrying the failing migration.
+	if err := Up(ctx, db); err == nil {
+		t.Fatal("expected Up to refuse to run against a dirty schema, got nil error")
+	}
+}
+
+func TestDown_RollsBackToTarget(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	if err := Up(ctx, db); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	if err := Down(ctx, db, ""); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		t.Fatalf("appliedVersions: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Fatalf("expected no applied migrations after full rollback, got %d", len(applied))
+	}
+
+	if _, err := db.ExecContext(ctx, `SELECT 1 FROM connections`); err == nil {
+		t.Fatal("expected connections table to be dropped after full rollback")
+	}
+}
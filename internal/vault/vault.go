@@ -0,0 +1,144 @@
+// Package vault manages the AES-256-GCM master key used to encrypt saved
+// connection DSNs at rest, backed by the OS keychain with a passphrase
+// fallback for environments where no keychain is available.
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	keyringService = "peeq"
+	keyringUser    = "master-key"
+)
+
+// MasterKey is the 32-byte AES-256-GCM key used to encrypt saved connection DSNs.
+type MasterKey [32]byte
+
+// LoadOrCreateMasterKey returns the master key stored in the OS keychain,
+// generating and storing a new random one on first run. If the keychain is
+// unavailable and passphrase is non-empty, the key is deterministically
+// derived from passphrase instead (see DeriveMasterKey) and never touches the
+// keychain.
+func LoadOrCreateMasterKey(passphrase string) (MasterKey, error) {
+	stored, err := keyring.Get(keyringService, keyringUser)
+	if err == nil {
+		return decodeMasterKey(stored)
+	}
+	if !errors.Is(err, keyring.ErrNotFound) {
+		if passphrase != "" {
+			return DeriveMasterKey(passphrase), nil
+		}
+		return MasterKey{}, fmt.Errorf("vault: keychain unavailable and no passphrase provided: %w", err)
+	}
+
+	key, err := GenerateMasterKey()
+	if err != nil {
+		return MasterKey{}, err
+	}
+	if err := StoreMasterKey(key); err != nil {
+		if passphrase != "" {
+			return DeriveMasterKey(passphrase), nil
+		}
+		return MasterKey{}, fmt.Errorf("vault: store master key in keychain: %w", err)
+	}
+	return key, nil
+}
+
+// GenerateMasterKey returns a fresh random 32-byte key.
+func GenerateMasterKey() (MasterKey, error) {
+	var key MasterKey
+	if _, err := rand.Read(key[:]); err != nil {
+		return MasterKey{}, fmt.Errorf("vault: generate master key: %w", err)
+	}
+	return key, nil
+}
+
+// StoreMasterKey persists key in the OS keychain, overwriting any existing entry.
+func StoreMasterKey(key MasterKey) error {
+	if err := keyring.Set(keyringService, keyringUser, encodeMasterKey(key)); err != nil {
+		return fmt.Errorf("vault: store master key: %w", err)
+	}
+	return nil
+}
+
+// DeriveMasterKey deterministically derives a 32-byte key from passphrase,
+// for use when the OS keychain is unavailable (e.g. headless CI, some Linux
+// setups without a secret service).
+func DeriveMasterKey(passphrase string) MasterKey {
+	return sha256.Sum256([]byte(passphrase))
+}
+
+func encodeMasterKey(k MasterKey) string {
+	return base64.StdEncoding.EncodeToString(k[:])
+}
+
+func decodeMasterKey(s string) (MasterKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(raw) != 32 {
+		return MasterKey{}, fmt.Errorf("vault: malformed master key in keychain")
+	}
+	var key MasterKey
+	copy(key[:], raw)
+	return key, nil
+}
+
+// Encrypt seals plaintext with AES-256-GCM under key, returning the
+// ciphertext and the randomly generated nonce used to produce it.
+func Encrypt(key MasterKey, plaintext string) (ciphertext, nonce []byte, err error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("vault: generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nil, nonce, []byte(plaintext), nil), nonce, nil
+}
+
+// Decrypt opens ciphertext sealed by Encrypt under key and nonce.
+func Decrypt(key MasterKey, ciphertext, nonce []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("vault: decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// Rotate re-encrypts ciphertext/nonce sealed under oldKey so it is sealed
+// under newKey instead.
+func Rotate(oldKey, newKey MasterKey, ciphertext, nonce []byte) (newCiphertext, newNonce []byte, err error) {
+	plaintext, err := Decrypt(oldKey, ciphertext, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	return Encrypt(newKey, plaintext)
+}
+
+func newGCM(key MasterKey) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("vault: init cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("vault: init GCM: %w", err)
+	}
+	return gcm, nil
+}